@@ -0,0 +1,108 @@
+package main
+
+import (
+	"context"
+	"sync"
+)
+
+// Allocator holds the memory-churn demo state that used to live in
+// package-level globals. All access goes through its methods so it can
+// be driven concurrently by the HTTP handler, the gRPC DemoService, and
+// the ticker goroutine, and reset between test runs.
+type Allocator struct {
+	mu sync.Mutex
+
+	desiredNbObjects int
+	objectsSizeInMB  int
+	nbObjects        int
+	globalSlice      []byte
+}
+
+// NewAllocator returns an Allocator that will grow to desiredNbObjects
+// objects of objectsSizeInMB megabytes each.
+func NewAllocator(desiredNbObjects, objectsSizeInMB int) *Allocator {
+	return &Allocator{
+		desiredNbObjects: desiredNbObjects,
+		objectsSizeInMB:  objectsSizeInMB,
+	}
+}
+
+// NbObjects returns how many objects have been allocated so far.
+func (a *Allocator) NbObjects() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.nbObjects
+}
+
+// DesiredNbObjects returns the configured allocation target.
+func (a *Allocator) DesiredNbObjects() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.desiredNbObjects
+}
+
+// ObjectsSizeInMB returns the configured per-object size.
+func (a *Allocator) ObjectsSizeInMB() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.objectsSizeInMB
+}
+
+// Configure updates the allocation target and per-object size at
+// runtime, e.g. from ConfigHandler.
+func (a *Allocator) Configure(desiredNbObjects, objectsSizeInMB int) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.desiredNbObjects = desiredNbObjects
+	a.objectsSizeInMB = objectsSizeInMB
+}
+
+// Tick allocates a single object of objectsSizeInMB if the desired count
+// hasn't been reached yet. It reports whether an object was allocated
+// and whether the desired count has now been reached.
+func (a *Allocator) Tick() (allocated, limitReached bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.nbObjects >= a.desiredNbObjects {
+		return false, true
+	}
+
+	size := 1024 * 1024 * a.objectsSizeInMB
+	data := make([]byte, size)
+	a.globalSlice = append(a.globalSlice, data...)
+	a.nbObjects++
+
+	allocatedObjects.Add(context.Background(), 1)
+	allocatedBytes.Record(context.Background(), int64(size))
+
+	return true, a.nbObjects >= a.desiredNbObjects
+}
+
+// Allocate grows the allocator by count objects of sizeInMB megabytes
+// each, on demand (e.g. from the gRPC Allocate RPC), regardless of
+// desiredNbObjects.
+func (a *Allocator) Allocate(sizeInMB, count int) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	size := 1024 * 1024 * sizeInMB
+	for i := 0; i < count; i++ {
+		data := make([]byte, size)
+		a.globalSlice = append(a.globalSlice, data...)
+		a.nbObjects++
+
+		allocatedObjects.Add(context.Background(), 1)
+		allocatedBytes.Record(context.Background(), int64(size))
+	}
+}
+
+// Reset clears all allocated objects so tests can exercise the allocator
+// repeatedly without restarting the process.
+func (a *Allocator) Reset() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.nbObjects = 0
+	a.globalSlice = nil
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"sync"
+	"sync/atomic"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// Config holds the ticker/message knobs that can be changed at runtime
+// through ConfigHandler, independently of the Allocator's own state.
+type Config struct {
+	mu             sync.Mutex
+	intervalInSecs int
+	customMessage  string
+}
+
+// NewConfig returns a Config seeded with the demo's default values.
+func NewConfig(intervalInSecs int, customMessage string) *Config {
+	return &Config{
+		intervalInSecs: intervalInSecs,
+		customMessage:  customMessage,
+	}
+}
+
+func (c *Config) IntervalInSecs() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.intervalInSecs
+}
+
+func (c *Config) CustomMessage() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.customMessage
+}
+
+// Set updates the interval and message under lock.
+func (c *Config) Set(intervalInSecs int, customMessage string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.intervalInSecs = intervalInSecs
+	c.customMessage = customMessage
+}
+
+// reloadableSampler is an sdktrace.Sampler that delegates every decision
+// to whatever sampler is currently loaded in its atomic.Pointer, so
+// ConfigHandler can swap the sampling ratio without restarting the
+// TracerProvider otelconfig.ConfigureOpenTelemetry created.
+type reloadableSampler struct {
+	current atomic.Pointer[sdktrace.Sampler]
+}
+
+// newReloadableSampler returns a reloadableSampler starting at ratio.
+func newReloadableSampler(ratio float64) *reloadableSampler {
+	s := &reloadableSampler{}
+	s.SetRatio(ratio)
+	return s
+}
+
+func (s *reloadableSampler) ShouldSample(p sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	sampler := s.current.Load()
+	return (*sampler).ShouldSample(p)
+}
+
+func (s *reloadableSampler) Description() string {
+	return "ReloadableSampler"
+}
+
+// SetRatio swaps the active sampler for a ParentBased(TraceIDRatioBased(ratio)).
+func (s *reloadableSampler) SetRatio(ratio float64) {
+	next := sdktrace.ParentBased(sdktrace.TraceIDRatioBased(ratio))
+	s.current.Store(&next)
+}
@@ -0,0 +1,61 @@
+// Package grpcserver serves DemoService over gRPC, instrumented with
+// otelgrpc so the same OTel pipeline that covers the HTTP server also
+// covers this transport.
+package grpcserver
+
+import (
+	"context"
+
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
+)
+
+// NewServer returns a grpc.Server with DemoServiceServer registered and
+// an otelgrpc stats handler installed, so every RPC produces a server
+// span under the global TracerProvider.
+func NewServer(srv DemoServiceServer) *grpc.Server {
+	s := grpc.NewServer(grpc.StatsHandler(otelgrpc.NewServerHandler()))
+	RegisterDemoServiceServer(s, srv)
+	return s
+}
+
+// NewTracedClientConn dials target with an otelgrpc client stats handler
+// installed, mirroring NewServer for the outbound path.
+func NewTracedClientConn(target string, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
+	opts = append(opts, grpc.WithStatsHandler(otelgrpc.NewClientHandler()))
+	return grpc.NewClient(target, opts...)
+}
+
+// Allocator is the subset of the allocator state DemoService needs to
+// serve GetStatus and Allocate.
+type Allocator interface {
+	Status() (nbInstances, intervalInSecs int, customMessage string)
+	Allocate(sizeInMB, count int)
+}
+
+// demoServiceServer implements DemoServiceServer against an Allocator.
+type demoServiceServer struct {
+	UnimplementedDemoServiceServer
+	allocator Allocator
+}
+
+// NewDemoServiceServer returns a DemoServiceServer backed by allocator.
+func NewDemoServiceServer(allocator Allocator) DemoServiceServer {
+	return &demoServiceServer{allocator: allocator}
+}
+
+func (s *demoServiceServer) GetStatus(ctx context.Context, req *GetStatusRequest) (*GetStatusResponse, error) {
+	nbInstances, intervalInSecs, customMessage := s.allocator.Status()
+	return &GetStatusResponse{
+		Status:         "ok",
+		NbInstances:    int32(nbInstances),
+		IntervalInSecs: int32(intervalInSecs),
+		CustomMessage:  customMessage,
+	}, nil
+}
+
+func (s *demoServiceServer) Allocate(ctx context.Context, req *AllocateRequest) (*AllocateResponse, error) {
+	s.allocator.Allocate(int(req.SizeInMb), int(req.Count))
+	nbInstances, _, _ := s.allocator.Status()
+	return &AllocateResponse{NbInstances: int32(nbInstances)}, nil
+}
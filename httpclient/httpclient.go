@@ -0,0 +1,24 @@
+// Package httpclient provides an outbound HTTP client instrumented with
+// OpenTelemetry, mirroring the HTTPClient/NewHTTPClient pattern from the
+// Jaeger HotROD example so downstream calls show up in the same trace as
+// the inbound request that triggered them.
+package httpclient
+
+import (
+	"net/http"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// NewTracedClient returns an *http.Client whose RoundTripper is wrapped in
+// otelhttp.Transport, so outbound requests made with it propagate W3C
+// traceparent headers and produce client spans under tp.
+func NewTracedClient(tp trace.TracerProvider) *http.Client {
+	return &http.Client{
+		Transport: otelhttp.NewTransport(
+			http.DefaultTransport,
+			otelhttp.WithTracerProvider(tp),
+		),
+	}
+}
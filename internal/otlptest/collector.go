@@ -0,0 +1,118 @@
+// Package otlptest provides an in-process mock OTLP collector for tests
+// that want to assert on exported telemetry without a network
+// dependency or a real collector running alongside the test.
+package otlptest
+
+import (
+	"context"
+	"net"
+	"sync"
+
+	collectorlogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	collectormetricspb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	collectortracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	logspb "go.opentelemetry.io/proto/otlp/logs/v1"
+	metricspb "go.opentelemetry.io/proto/otlp/metrics/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/grpc"
+)
+
+// Collector is an in-process gRPC server implementing the OTLP trace,
+// logs and metrics collector services. It buffers everything it
+// receives so a test can assert on it after exercising the app under
+// test.
+type Collector struct {
+	mu      sync.Mutex
+	spans   []*tracepb.ResourceSpans
+	logs    []*logspb.ResourceLogs
+	metrics []*metricspb.ResourceMetrics
+
+	srv *grpc.Server
+	lis net.Listener
+}
+
+// Start listens on an OS-assigned localhost port and serves the mock
+// collector in the background. Point OTEL_EXPORTER_OTLP_ENDPOINT at
+// c.Addr() to export to it.
+func Start() (*Collector, error) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Collector{srv: grpc.NewServer(), lis: lis}
+	collectortracepb.RegisterTraceServiceServer(c.srv, &traceServer{c: c})
+	collectorlogspb.RegisterLogsServiceServer(c.srv, &logsServer{c: c})
+	collectormetricspb.RegisterMetricsServiceServer(c.srv, &metricsServer{c: c})
+
+	go c.srv.Serve(lis)
+
+	return c, nil
+}
+
+// Addr returns the host:port the mock collector is listening on.
+func (c *Collector) Addr() string {
+	return c.lis.Addr().String()
+}
+
+// Stop gracefully stops the mock collector.
+func (c *Collector) Stop() {
+	c.srv.GracefulStop()
+}
+
+// Spans returns the ResourceSpans received so far.
+func (c *Collector) Spans() []*tracepb.ResourceSpans {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]*tracepb.ResourceSpans(nil), c.spans...)
+}
+
+// Logs returns the ResourceLogs received so far.
+func (c *Collector) Logs() []*logspb.ResourceLogs {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]*logspb.ResourceLogs(nil), c.logs...)
+}
+
+// Metrics returns the ResourceMetrics received so far.
+func (c *Collector) Metrics() []*metricspb.ResourceMetrics {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]*metricspb.ResourceMetrics(nil), c.metrics...)
+}
+
+type traceServer struct {
+	collectortracepb.UnimplementedTraceServiceServer
+	c *Collector
+}
+
+func (s *traceServer) Export(ctx context.Context, req *collectortracepb.ExportTraceServiceRequest) (*collectortracepb.ExportTraceServiceResponse, error) {
+	s.c.mu.Lock()
+	s.c.spans = append(s.c.spans, req.GetResourceSpans()...)
+	s.c.mu.Unlock()
+	return &collectortracepb.ExportTraceServiceResponse{}, nil
+}
+
+type logsServer struct {
+	collectorlogspb.UnimplementedLogsServiceServer
+	c *Collector
+}
+
+func (s *logsServer) Export(ctx context.Context, req *collectorlogspb.ExportLogsServiceRequest) (*collectorlogspb.ExportLogsServiceResponse, error) {
+	s.c.mu.Lock()
+	s.c.logs = append(s.c.logs, req.GetResourceLogs()...)
+	s.c.mu.Unlock()
+	return &collectorlogspb.ExportLogsServiceResponse{}, nil
+}
+
+type metricsServer struct {
+	collectormetricspb.UnimplementedMetricsServiceServer
+	c *Collector
+}
+
+func (s *metricsServer) Export(ctx context.Context, req *collectormetricspb.ExportMetricsServiceRequest) (*collectormetricspb.ExportMetricsServiceResponse, error) {
+	s.c.mu.Lock()
+	s.c.metrics = append(s.c.metrics, req.GetResourceMetrics()...)
+	s.c.mu.Unlock()
+	return &collectormetricspb.ExportMetricsServiceResponse{}, nil
+}
@@ -2,34 +2,75 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"log"
+	"net"
 	"net/http"
 	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
 	"time"
 
+	"github.com/Kheops-org/demo-go-server/grpcserver"
+	"github.com/Kheops-org/demo-go-server/httpclient"
 	"github.com/hyperdxio/opentelemetry-go/otelzap"
 	"github.com/hyperdxio/opentelemetry-logs-go/exporters/otlp/otlplogs"
 	sdk "github.com/hyperdxio/opentelemetry-logs-go/sdk/logs"
 	"github.com/hyperdxio/otel-config-go/otelconfig"
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/contrib/instrumentation/runtime"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+	"go.opentelemetry.io/otel/metric"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
 	"go.opentelemetry.io/otel/sdk/resource"
 	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
 	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
 )
 
-var desiredNbObjects = 7
-var objectsSizeInMB = 1
-var intervalInSecs = 5
-var customMessage = "Hello 7 objects"
+var allocator = NewAllocator(7, 1)
+var cfg = NewConfig(5, "Hello 7 objects")
+var sampler = newReloadableSampler(1.0)
 
-var globalSlice []byte
-var nbObjects int = 0
-var done = make(chan bool)
+// reconfigure signals the ticker goroutine to pick up a new interval, or
+// to resume ticking after the allocator's desired count was raised past
+// a previously reached limit, following a PUT /config call. Buffered so
+// ConfigHandler never blocks on it.
+var reconfigure = make(chan struct{}, 1)
 
-// configure common attributes for all logs
+var allocatedObjects metric.Int64UpDownCounter
+var allocatedBytes metric.Int64Histogram
+
+// tracedClient is used by ChainHandler to make an outbound HTTP call that
+// continues the trace of the inbound request.
+var tracedClient *http.Client
+
+// tracedGRPCClient is used by ChainHandler to make an outbound gRPC call
+// that continues the same trace, so both transports show up as children
+// of the same span. Dialed against this process's own gRPC listener,
+// since the demo has no other gRPC service to call.
+var tracedGRPCClient grpcserver.DemoServiceClient
+
+// legacyAllocator adapts allocator and cfg to grpcserver.Allocator so
+// DemoService can drive the same state the HTTP handlers do.
+type legacyAllocator struct{}
+
+func (legacyAllocator) Status() (nbInstances, intervalSecs int, message string) {
+	return allocator.NbObjects(), cfg.IntervalInSecs(), cfg.CustomMessage()
+}
+
+func (legacyAllocator) Allocate(sizeInMB, count int) {
+	allocator.Allocate(sizeInMB, count)
+}
+
+// configure common attributes for all logs and metrics
 func newResource() *resource.Resource {
 	hostName, _ := os.Hostname()
 	return resource.NewWithAttributes(
@@ -39,80 +80,250 @@ func newResource() *resource.Resource {
 	)
 }
 
-// attach trace id to the log
-func WithTraceMetadata(ctx context.Context, logger *zap.Logger) *zap.Logger {
+// attach trace id to the log. logger.Ctx attaches the span context so
+// the OTLP log record carries native trace_id/span_id fields; the
+// string fields below additionally make them readable in the raw log
+// message for backends that don't surface the native fields.
+func WithTraceMetadata(ctx context.Context, logger *otelzap.Logger) *otelzap.Logger {
 	spanContext := trace.SpanContextFromContext(ctx)
 	if !spanContext.IsValid() {
 		// ctx does not contain a valid span.
 		// There is no trace metadata to add.
 		return logger
 	}
-	return logger.With(
+	return logger.Ctx(ctx).With(
 		zap.String("trace_id", spanContext.TraceID().String()),
 		zap.String("span_id", spanContext.SpanID().String()),
 	)
 }
 
+// metricsEnabled reports whether OTEL_METRICS_ENABLED - the same var
+// otelconfig's own Config.MetricsEnabled reads, default true - asks for
+// metrics. Unset or unparseable values are treated as enabled.
+func metricsEnabled() bool {
+	v, ok := os.LookupEnv("OTEL_METRICS_ENABLED")
+	if !ok {
+		return true
+	}
+	enabled, err := strconv.ParseBool(v)
+	if err != nil {
+		return true
+	}
+	return enabled
+}
+
+// configure the OTLP metric pipeline. Reads the same OTEL_EXPORTER_OTLP_*
+// env vars otelconfig already honors. otelconfig.ConfigureOpenTelemetry is
+// called with WithMetricsEnabled(false) so its own metrics/host/runtime
+// pipeline never runs, leaving this as the only metrics pipeline; it's
+// gated on OTEL_METRICS_ENABLED instead for consistency with otelconfig,
+// which never reads OTEL_METRICS_EXPORTER. Disabling it still creates the
+// instruments below so calls into them are no-ops rather than panics.
+//
+// Instruments are created from mp directly rather than otel.Meter, since
+// the global meter only ever delegates to the first MeterProvider it's
+// given; run can be called more than once per process (e.g. in tests),
+// and each call needs its counters bound to its own provider.
+func newMeterProvider(ctx context.Context) (*sdkmetric.MeterProvider, error) {
+	var err error
+	mpOpts := []sdkmetric.Option{sdkmetric.WithResource(newResource())}
+
+	if metricsEnabled() {
+		var metricExporter *otlpmetricgrpc.Exporter
+		metricExporter, err = otlpmetricgrpc.New(ctx)
+		if err != nil {
+			return nil, err
+		}
+		mpOpts = append(mpOpts, sdkmetric.WithReader(sdkmetric.NewPeriodicReader(metricExporter)))
+	}
+
+	mp := sdkmetric.NewMeterProvider(mpOpts...)
+	otel.SetMeterProvider(mp)
+
+	if err := runtime.Start(runtime.WithMeterProvider(mp)); err != nil {
+		return nil, err
+	}
+
+	meter := mp.Meter("demo-go-server")
+	allocatedObjects, err = meter.Int64UpDownCounter(
+		"demo.allocated_objects",
+		metric.WithDescription("Number of objects currently held by the allocator"),
+		metric.WithUnit("{object}"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	allocatedBytes, err = meter.Int64Histogram(
+		"demo.allocated_bytes",
+		metric.WithDescription("Size of objects allocated by the Allocator"),
+		metric.WithUnit("By"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return mp, nil
+}
+
 func main() {
 	// Initialize otel config and use it across the entire app
 	println("Service starting up")
 
-	otelShutdown, err := otelconfig.ConfigureOpenTelemetry()
-	if err != nil {
-		log.Fatalf("error setting up OTel SDK - %e", err)
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := run(ctx); err != nil {
+		log.Fatalf("error running service - %v", err)
 	}
-	defer otelShutdown()
+}
 
-	ctx := context.Background()
+// run wires up telemetry and serves HTTP and gRPC until ctx is
+// cancelled, then flushes telemetry and returns. Split out of main so
+// integration tests can drive it with a context they control.
+func run(ctx context.Context) error {
+	// otelconfig runs its own metrics/host/runtime pipeline by default;
+	// disable it so newMeterProvider's pipeline is the only one exporting,
+	// instead of two independent MeterProviders racing to export (and
+	// otelconfig's own host.Start metrics showing up unasked for).
+	otelShutdown, err := otelconfig.ConfigureOpenTelemetry(
+		otelconfig.WithSampler(sampler),
+		otelconfig.WithMetricsEnabled(false),
+	)
+	if err != nil {
+		return fmt.Errorf("error setting up OTel SDK: %w", err)
+	}
 
 	// configure opentelemetry logger provider
+	//
+	// sdk.WithBatcher's processor has a known data race between its export
+	// goroutine and OnEmit (hyperdxio/opentelemetry-logs-go's
+	// batchLogRecordProcessor.OnEmit has a value receiver, so every call
+	// copies the processor's batch/queue state while the export goroutine
+	// mutates it) - it trips go test -race as soon as a handler logs
+	// concurrently with a pending export. Use the synchronous processor
+	// until that's fixed upstream; this demo's log volume doesn't need
+	// batching.
 	logExporter, _ := otlplogs.NewExporter(ctx)
 	loggerProvider := sdk.NewLoggerProvider(
-		sdk.WithBatcher(logExporter),
+		sdk.WithSyncer(logExporter),
 	)
-	// gracefully shutdown logger to flush accumulated signals before program finish
-	defer loggerProvider.Shutdown(ctx)
+
+	// configure opentelemetry meter provider, parallel to the logger provider above
+	meterProvider, err := newMeterProvider(ctx)
+	if err != nil {
+		return fmt.Errorf("error setting up OTel meter provider: %w", err)
+	}
 
 	// create new logger with opentelemetry zap core and set it globally
-	logger := zap.New(otelzap.NewOtelCore(loggerProvider))
-	zap.ReplaceGlobals(logger)
+	logger := &otelzap.Logger{Logger: zap.New(otelzap.NewOtelCore(loggerProvider))}
+	zap.ReplaceGlobals(logger.Logger)
 	logger.Warn("hello world", zap.String("foo", "bar"))
 
-	interval := time.Second * time.Duration(intervalInSecs)
-	ticker := time.NewTicker(interval)
-	defer ticker.Stop()
+	tracedClient = httpclient.NewTracedClient(otel.GetTracerProvider())
+
+	mux := http.NewServeMux()
+	mux.Handle("/", otelhttp.NewHandler(wrapHandler(logger, ExampleHandler), "example-service"))
+	mux.Handle("/chain", otelhttp.NewHandler(wrapHandler(logger, ChainHandler), "chain-service"))
+	mux.Handle("/config", otelhttp.NewHandler(wrapHandler(logger, ConfigHandler), "config-service"))
+
+	port := os.Getenv("PORT")
+	if port == "" {
+		port = "8080"
+	}
+	srv := &http.Server{Addr: ":" + port, Handler: mux}
+
+	grpcPort := os.Getenv("GRPC_PORT")
+	if grpcPort == "" {
+		grpcPort = "9090"
+	}
+	grpcListener, err := net.Listen("tcp", ":"+grpcPort)
+	if err != nil {
+		return fmt.Errorf("error listening on gRPC port %s: %w", grpcPort, err)
+	}
+	grpcSrv := grpcserver.NewServer(grpcserver.NewDemoServiceServer(legacyAllocator{}))
+
+	grpcConn, err := grpcserver.NewTracedClientConn("localhost:"+grpcPort, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return fmt.Errorf("error dialing gRPC server for ChainHandler: %w", err)
+	}
+	defer grpcConn.Close()
+	tracedGRPCClient = grpcserver.NewDemoServiceClient(grpcConn)
 
-	// Use a channel to signal when to stop
-	// done := make(chan bool)
+	g, gctx := errgroup.WithContext(ctx)
 
-	// Start a goroutine to run the function every interval
-	go func() {
+	g.Go(func() error {
+		ticker := time.NewTicker(time.Second * time.Duration(cfg.IntervalInSecs()))
+		defer ticker.Stop()
 		for {
 			select {
-			case <-done:
-				return
+			case <-gctx.Done():
+				return nil
+			case <-reconfigure:
+				ticker.Stop()
+				ticker = time.NewTicker(time.Second * time.Duration(cfg.IntervalInSecs()))
 			case t := <-ticker.C:
-				recurrentFunction(t)
+				// Stop (rather than return) once the limit is reached: a
+				// stopped ticker's channel never fires again, so this
+				// goroutine keeps idling on reconfigure without waking up
+				// on every tick. A later reconfigure — e.g. desired_nb_objects
+				// raised past the old limit — installs a fresh ticker and
+				// ramp-up resumes.
+				if tickAllocator(t) {
+					ticker.Stop()
+				}
 			}
 		}
-	}()
+	})
 
-	http.Handle("/", otelhttp.NewHandler(wrapHandler(logger, ExampleHandler), "example-service"))
+	g.Go(func() error {
+		logger.Info("** Service Started on Port " + port + " **")
+		println("** Service Started on Port " + port + " **")
+		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			return err
+		}
+		return nil
+	})
 
-	port := os.Getenv("PORT")
-	if port == "" {
-		port = "8080"
+	g.Go(func() error {
+		logger.Info("** gRPC Service Started on Port " + grpcPort + " **")
+		println("** gRPC Service Started on Port " + grpcPort + " **")
+		if err := grpcSrv.Serve(grpcListener); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	g.Go(func() error {
+		<-gctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		grpcSrv.GracefulStop()
+		return srv.Shutdown(shutdownCtx)
+	})
+
+	runErr := g.Wait()
+	if runErr != nil {
+		logger.Error("server exited with error", zap.Error(runErr))
 	}
 
-	logger.Info("** Service Started on Port " + port + " **")
-	println("** Service Started on Port " + port + " **")
-	if err := http.ListenAndServe(":"+port, nil); err != nil {
-		logger.Fatal(err.Error())
+	// flush the telemetry providers in the reverse order they were set up,
+	// bounded so shutdown can't hang if an exporter is unreachable.
+	flushCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := meterProvider.Shutdown(flushCtx); err != nil {
+		logger.Error("error shutting down meter provider", zap.Error(err))
 	}
+	if err := loggerProvider.Shutdown(flushCtx); err != nil {
+		logger.Error("error shutting down logger provider", zap.Error(err))
+	}
+	otelShutdown()
+
+	return runErr
 }
 
 // Use this to wrap all handlers to add trace metadata to the logger
-func wrapHandler(logger *zap.Logger, handler http.HandlerFunc) http.HandlerFunc {
+func wrapHandler(logger *otelzap.Logger, handler http.HandlerFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		logger := WithTraceMetadata(r.Context(), logger)
 		logger.Info("request received", zap.String("url", r.URL.Path), zap.String("method", r.Method))
@@ -123,20 +334,145 @@ func wrapHandler(logger *zap.Logger, handler http.HandlerFunc) http.HandlerFunc
 
 func ExampleHandler(w http.ResponseWriter, r *http.Request) {
 	w.Header().Add("Content-Type", "application/json")
-	output := fmt.Sprintf(`{"status":"ok","nbInstances":"%d","intervalInSecs":"%d","customMessage":"%s"}`, nbObjects, intervalInSecs, customMessage)
+	output := fmt.Sprintf(`{"status":"ok","nbInstances":"%d","intervalInSecs":"%d","customMessage":"%s"}`, allocator.NbObjects(), cfg.IntervalInSecs(), cfg.CustomMessage())
 	io.WriteString(w, output)
 }
 
-func recurrentFunction(t time.Time) {
+// configPayload is the JSON body ConfigHandler reads and writes. Pointer
+// fields on PUT so a caller only needs to send the values it wants to
+// change. GET populates every field except sampling_ratio, which is
+// accepted on PUT but never reported back.
+type configPayload struct {
+	DesiredNbObjects *int     `json:"desired_nb_objects,omitempty"`
+	ObjectsSizeInMB  *int     `json:"objects_size_in_mb,omitempty"`
+	IntervalInSecs   *int     `json:"interval_in_secs,omitempty"`
+	CustomMessage    *string  `json:"custom_message,omitempty"`
+	SamplingRatio    *float64 `json:"sampling_ratio,omitempty"`
+}
+
+// ConfigHandler exposes the allocator, ticker and sampler knobs at
+// runtime: GET returns the current allocator and ticker values (not the
+// sampling ratio), PUT applies any fields present in the JSON body
+// without requiring a restart.
+func ConfigHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Add("Content-Type", "application/json")
+
+	switch r.Method {
+	case http.MethodGet:
+		writeConfig(w)
+	case http.MethodPut:
+		var payload configPayload
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if payload.IntervalInSecs != nil && *payload.IntervalInSecs <= 0 {
+			http.Error(w, "interval_in_secs must be positive", http.StatusBadRequest)
+			return
+		}
+
+		desiredNbObjects, objectsSizeInMB := allocator.DesiredNbObjects(), allocator.ObjectsSizeInMB()
+		if payload.DesiredNbObjects != nil {
+			desiredNbObjects = *payload.DesiredNbObjects
+		}
+		if payload.ObjectsSizeInMB != nil {
+			objectsSizeInMB = *payload.ObjectsSizeInMB
+		}
+		allocator.Configure(desiredNbObjects, objectsSizeInMB)
+
+		intervalInSecs, customMessage := cfg.IntervalInSecs(), cfg.CustomMessage()
+		if payload.IntervalInSecs != nil {
+			intervalInSecs = *payload.IntervalInSecs
+		}
+		if payload.CustomMessage != nil {
+			customMessage = *payload.CustomMessage
+		}
+		cfg.Set(intervalInSecs, customMessage)
+		// Also nudge the ticker goroutine when desired_nb_objects changes:
+		// if it had already stopped after reaching the old limit, this is
+		// what lets ramp-up resume without a restart.
+		if payload.IntervalInSecs != nil || payload.DesiredNbObjects != nil {
+			select {
+			case reconfigure <- struct{}{}:
+			default:
+			}
+		}
+
+		if payload.SamplingRatio != nil {
+			sampler.SetRatio(*payload.SamplingRatio)
+		}
+
+		writeConfig(w)
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func writeConfig(w http.ResponseWriter) {
+	desiredNbObjects, objectsSizeInMB := allocator.DesiredNbObjects(), allocator.ObjectsSizeInMB()
+	intervalInSecs, customMessage := cfg.IntervalInSecs(), cfg.CustomMessage()
+	json.NewEncoder(w).Encode(configPayload{
+		DesiredNbObjects: &desiredNbObjects,
+		ObjectsSizeInMB:  &objectsSizeInMB,
+		IntervalInSecs:   &intervalInSecs,
+		CustomMessage:    &customMessage,
+	})
+}
+
+// ChainHandler calls DOWNSTREAM_URL over HTTP and DemoService.GetStatus
+// over gRPC using the request's context, so both the W3C traceparent
+// header and the gRPC otelgrpc client stats handler propagate the trace,
+// and both downstream calls show up as children of the span wrapHandler
+// already annotates.
+func ChainHandler(w http.ResponseWriter, r *http.Request) {
+	downstreamURL := os.Getenv("DOWNSTREAM_URL")
+	if downstreamURL == "" {
+		downstreamURL = "https://httpbin.org/get"
+	}
+
+	req, err := http.NewRequestWithContext(r.Context(), http.MethodGet, downstreamURL, nil)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	resp, err := tracedClient.Do(req)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+
+	status, err := tracedGRPCClient.GetStatus(r.Context(), &grpcserver.GetStatusRequest{})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+
+	spanContext := trace.SpanContextFromContext(r.Context())
+	w.Header().Add("Content-Type", "application/json")
+	output := fmt.Sprintf(`{"downstream_body":%q,"grpc_nb_instances":%d,"trace_id":"%s"}`, body, status.GetNbInstances(), spanContext.TraceID().String())
+	io.WriteString(w, output)
+}
+
+// tickAllocator drives one tick of the allocator ramp-up and reports
+// whether the desired object count has been reached, at which point the
+// caller should stop the ticker.
+func tickAllocator(t time.Time) bool {
 	formattedTime := t.Format("2006-01-02 15:04:05")
-	fmt.Printf("%v: Allocated objects: %d\n", formattedTime, nbObjects)
-	if nbObjects < desiredNbObjects {
+	fmt.Printf("%v: Allocated objects: %d\n", formattedTime, allocator.NbObjects())
+
+	allocated, limitReached := allocator.Tick()
+	if allocated {
 		fmt.Printf("%v: Allocating new object\n", formattedTime)
-		data := make([]byte, 1024*1024*objectsSizeInMB)
-		globalSlice = append(globalSlice, data...)
-		nbObjects++
 	} else {
-		fmt.Printf("%v: Objects limit reached (%d), no new allocation, stopping ticker\n", formattedTime, desiredNbObjects)
-		done <- true
+		fmt.Printf("%v: Objects limit reached (%d), no new allocation, stopping ticker\n", formattedTime, allocator.DesiredNbObjects())
 	}
+	return limitReached
 }
@@ -0,0 +1,313 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Kheops-org/demo-go-server/internal/otlptest"
+)
+
+// waitUntil polls fn until it returns true or timeout elapses, failing
+// the test if it never does. Exported telemetry is batched, so tests
+// that assert on it need to poll rather than read once.
+func waitUntil(t *testing.T, timeout time.Duration, fn func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		if fn() {
+			return
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	t.Fatalf("condition not met after %s", timeout)
+}
+
+// startService boots the full service (HTTP, gRPC, telemetry) against
+// an in-process mock collector and returns the collector plus the
+// service's base URL. It registers a t.Cleanup that cancels the service,
+// waits for it to stop and stops the collector.
+func startService(t *testing.T) (*otlptest.Collector, string) {
+	t.Helper()
+
+	collector, err := otlptest.Start()
+	if err != nil {
+		t.Fatalf("starting mock collector: %v", err)
+	}
+
+	os.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "http://"+collector.Addr())
+	os.Setenv("OTEL_EXPORTER_OTLP_PROTOCOL", "grpc")
+	os.Setenv("OTEL_EXPORTER_OTLP_INSECURE", "true")
+	// The SDK's default metric export interval is 60s, far longer than
+	// these tests want to wait; shorten it so exported metrics show up
+	// promptly in the mock collector.
+	os.Setenv("OTEL_METRIC_EXPORT_INTERVAL", "200")
+	os.Setenv("PORT", "18080")
+	os.Setenv("GRPC_PORT", "19090")
+	os.Setenv("OTEL_SERVICE_NAME", "demo-go-server-test")
+
+	allocator.Reset()
+	allocator.Configure(2, 1)
+	cfg.Set(1, "test message")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- run(ctx)
+	}()
+
+	baseURL := "http://localhost:18080"
+	waitUntil(t, 5*time.Second, func() bool {
+		resp, err := http.Get(baseURL + "/")
+		if err != nil {
+			return false
+		}
+		resp.Body.Close()
+		return true
+	})
+
+	t.Cleanup(func() {
+		cancel()
+		<-done
+		collector.Stop()
+	})
+
+	return collector, baseURL
+}
+
+func TestExampleHandlerExportsTraceAndLogs(t *testing.T) {
+	collector, baseURL := startService(t)
+
+	resp, err := http.Get(baseURL + "/")
+	if err != nil {
+		t.Fatalf("GET / failed: %v", err)
+	}
+	resp.Body.Close()
+
+	var route string
+	var traceID, spanID []byte
+	waitUntil(t, 10*time.Second, func() bool {
+		for _, rs := range collector.Spans() {
+			for _, ss := range rs.GetScopeSpans() {
+				for _, span := range ss.GetSpans() {
+					for _, attr := range span.GetAttributes() {
+						if attr.GetKey() == "http.route" {
+							route = attr.GetValue().GetStringValue()
+							if route == "/" {
+								traceID, spanID = span.GetTraceId(), span.GetSpanId()
+								return true
+							}
+						}
+					}
+				}
+			}
+		}
+		return false
+	})
+	if route != "/" {
+		t.Fatalf("expected a server span with http.route=/, got %q", route)
+	}
+
+	waitUntil(t, 10*time.Second, func() bool {
+		for _, rl := range collector.Logs() {
+			for _, sl := range rl.GetScopeLogs() {
+				for _, record := range sl.GetLogRecords() {
+					if bytes.Equal(record.GetTraceId(), traceID) && bytes.Equal(record.GetSpanId(), spanID) {
+						return true
+					}
+				}
+			}
+		}
+		return false
+	})
+}
+
+func TestExampleHandlerRecordsRequestDurationMetric(t *testing.T) {
+	collector, baseURL := startService(t)
+
+	resp, err := http.Get(baseURL + "/")
+	if err != nil {
+		t.Fatalf("GET / failed: %v", err)
+	}
+	resp.Body.Close()
+
+	waitUntil(t, 10*time.Second, func() bool {
+		for _, rm := range collector.Metrics() {
+			for _, sm := range rm.GetScopeMetrics() {
+				for _, m := range sm.GetMetrics() {
+					if m.GetName() == "http.server.request.duration" {
+						return true
+					}
+				}
+			}
+		}
+		return false
+	})
+}
+
+// allocatedObjectsValues returns every demo.allocated_objects data point
+// value exported so far, repeats included, in the order the exports
+// arrived.
+func allocatedObjectsValues(collector *otlptest.Collector) []int64 {
+	var values []int64
+	for _, rm := range collector.Metrics() {
+		for _, sm := range rm.GetScopeMetrics() {
+			for _, m := range sm.GetMetrics() {
+				if m.GetName() != "demo.allocated_objects" {
+					continue
+				}
+				for _, dp := range m.GetSum().GetDataPoints() {
+					values = append(values, dp.GetAsInt())
+				}
+			}
+		}
+	}
+	return values
+}
+
+// TestMetricsPipelineIsNotDuplicated guards against otelconfig running its
+// own metrics pipeline alongside newMeterProvider's: if it ever reappears,
+// the mock collector would start receiving otelconfig's host/runtime
+// metrics (e.g. process.cpu.time) in addition to the ones this service
+// defines itself.
+func TestMetricsPipelineIsNotDuplicated(t *testing.T) {
+	collector, baseURL := startService(t)
+
+	resp, err := http.Get(baseURL + "/")
+	if err != nil {
+		t.Fatalf("GET / failed: %v", err)
+	}
+	resp.Body.Close()
+
+	waitUntil(t, 10*time.Second, func() bool {
+		for _, rm := range collector.Metrics() {
+			for _, sm := range rm.GetScopeMetrics() {
+				for _, m := range sm.GetMetrics() {
+					if m.GetName() == "http.server.request.duration" {
+						return true
+					}
+				}
+			}
+		}
+		return false
+	})
+
+	for _, rm := range collector.Metrics() {
+		for _, sm := range rm.GetScopeMetrics() {
+			for _, m := range sm.GetMetrics() {
+				if strings.HasPrefix(m.GetName(), "process.") || strings.HasPrefix(m.GetName(), "system.") {
+					t.Fatalf("unexpected otelconfig host metric exported: %s", m.GetName())
+				}
+			}
+		}
+	}
+}
+
+func TestTickAllocatorEmitsAllocatorProgression(t *testing.T) {
+	collector, _ := startService(t)
+
+	waitUntil(t, 10*time.Second, func() bool {
+		return allocator.NbObjects() >= allocator.DesiredNbObjects()
+	})
+
+	// The allocator ramps up one object per tick, so the exported
+	// counter should show more than one distinct value on its way to
+	// the final count, not just a single post-hoc export.
+	waitUntil(t, 10*time.Second, func() bool {
+		values := allocatedObjectsValues(collector)
+		if len(values) < 2 {
+			return false
+		}
+		for i := 1; i < len(values); i++ {
+			if values[i] < values[i-1] {
+				t.Fatalf("demo.allocated_objects regressed: %v", values)
+			}
+		}
+		return values[len(values)-1] > values[0]
+	})
+}
+
+func TestConfigHandlerUpdatesAllocatorAndTicker(t *testing.T) {
+	_, baseURL := startService(t)
+
+	resp, err := http.Get(baseURL + "/config")
+	if err != nil {
+		t.Fatalf("GET /config failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("GET /config: expected 200, got %d", resp.StatusCode)
+	}
+
+	body := strings.NewReader(`{"desired_nb_objects":5,"interval_in_secs":2}`)
+	req, err := http.NewRequest(http.MethodPut, baseURL+"/config", body)
+	if err != nil {
+		t.Fatalf("building PUT /config request: %v", err)
+	}
+	putResp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("PUT /config failed: %v", err)
+	}
+	putResp.Body.Close()
+
+	waitUntil(t, time.Second, func() bool {
+		return allocator.DesiredNbObjects() == 5 && cfg.IntervalInSecs() == 2
+	})
+}
+
+// TestConfigHandlerRejectsNonPositiveInterval guards against a
+// non-positive interval_in_secs reaching time.NewTicker, which panics.
+func TestConfigHandlerRejectsNonPositiveInterval(t *testing.T) {
+	_, baseURL := startService(t)
+
+	for _, interval := range []int{0, -1} {
+		body := strings.NewReader(fmt.Sprintf(`{"interval_in_secs":%d}`, interval))
+		req, err := http.NewRequest(http.MethodPut, baseURL+"/config", body)
+		if err != nil {
+			t.Fatalf("building PUT /config request: %v", err)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("PUT /config failed: %v", err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusBadRequest {
+			t.Fatalf("interval_in_secs=%d: expected 400, got %d", interval, resp.StatusCode)
+		}
+	}
+
+	if got := cfg.IntervalInSecs(); got <= 0 {
+		t.Fatalf("rejected PUT must not have reached the ticker's config, got interval %d", got)
+	}
+}
+
+// TestConfigHandlerResumesTickerAfterLimitReached guards against the
+// ticker goroutine exiting for good once the allocator hits its limit:
+// raising desired_nb_objects afterwards must let the ramp-up continue.
+func TestConfigHandlerResumesTickerAfterLimitReached(t *testing.T) {
+	_, baseURL := startService(t)
+
+	waitUntil(t, 10*time.Second, func() bool {
+		return allocator.NbObjects() >= allocator.DesiredNbObjects()
+	})
+
+	body := strings.NewReader(`{"desired_nb_objects":5}`)
+	req, err := http.NewRequest(http.MethodPut, baseURL+"/config", body)
+	if err != nil {
+		t.Fatalf("building PUT /config request: %v", err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("PUT /config failed: %v", err)
+	}
+	resp.Body.Close()
+
+	waitUntil(t, 10*time.Second, func() bool {
+		return allocator.NbObjects() >= 5
+	})
+}